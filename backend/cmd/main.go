@@ -1,26 +1,37 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/auth/oauth"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/config"
 	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
 	"github.com/Neroframe/FarmerMarketSystem/backend/internal/handlers"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/httputil"
 	"github.com/Neroframe/FarmerMarketSystem/backend/internal/middleware"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/session"
 	_ "github.com/lib/pq"
 )
 
 func main() {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal("DATABASE_URL is not set")
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
 	}
+	log.Printf("Config: %s", cfg.Summary())
 
-	dbConn, err := db.NewPostgresDB(dbURL)
+	dbConn, err := db.NewPostgresDB(cfg.DatabaseURL)
 	if err != nil {
 		log.Fatalf("Failed to connect to the database: %v", err)
 	}
@@ -36,70 +47,197 @@ func main() {
 		log.Fatalf("Error parsing templates: %v", err)
 	}
 
-	adminHandler := handlers.NewAdminHandler(dbConn, templates)
-	farmerHandler := handlers.NewFarmerHandler(dbConn, templates)
-	buyerHandler := handlers.NewBuyerHandler(dbConn, templates)
-	productHandler := handlers.NewProductHandler(dbConn, templates)
+	sessionStore, err := session.NewStore(cfg, dbConn)
+	if err != nil {
+		log.Fatalf("Failed to build session store: %v", err)
+	}
+	sessionSigner := session.NewSigner(cfg.SessionSecret)
+
+	adminHandler := handlers.NewAdminHandler(dbConn, templates, sessionStore, sessionSigner, cfg.SecureCookies(), cfg.SessionTTL)
+	farmerHandler := handlers.NewFarmerHandler(dbConn, templates, sessionStore, sessionSigner, cfg.SecureCookies(), cfg.SessionTTL)
+	buyerHandler := handlers.NewBuyerHandler(dbConn, templates, sessionStore, sessionSigner, cfg.SecureCookies(), cfg.SessionTTL)
+	productHandler := handlers.NewProductHandler(dbConn)
 	cartHandler := handlers.NewCartHandler(dbConn)
 
-	http.Handle("/favicon.ico", http.HandlerFunc(http.NotFound))
-
-	// Admin routes
-	http.HandleFunc("/", adminHandler.Root)
-	http.HandleFunc("/admin/register", adminHandler.Register)
-	http.HandleFunc("/admin/login", adminHandler.Login)
-	http.Handle("/admin/logout", middleware.Authenticate(dbConn, http.HandlerFunc(adminHandler.Logout)))
-
-	http.Handle("/admin/dashboard", middleware.Authenticate(dbConn, http.HandlerFunc(adminHandler.Dashboard)))
-	http.Handle("/admin/dashboard/pending-farmers", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(farmerHandler.ListPendingFarmers))))
-	http.Handle("/admin/dashboard/farmer-profile", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(farmerHandler.ViewFarmerProfile))))
-	http.Handle("/admin/dashboard/approve-farmer", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(farmerHandler.ApproveFarmer))))
-	http.Handle("/admin/dashboard/reject-farmer", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(farmerHandler.RejectFarmer))))
-
-	http.Handle("/admin/users", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(adminHandler.ListUsers))))
-
-	http.Handle("/admin/users/toggle-farmer-status", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(farmerHandler.ToggleFarmerStatus))))
-	http.Handle("/admin/users/edit-farmer", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(farmerHandler.EditFarmer))))
-	http.Handle("/admin/users/delete-farmer", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(farmerHandler.DeleteFarmer))))
-
-	http.Handle("/admin/users/toggle-buyer-status", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(buyerHandler.ToggleBuyerStatus))))
-	http.Handle("/admin/users/edit-buyer", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(buyerHandler.EditBuyer))))
-	http.Handle("/admin/users/delete-buyer", middleware.Authenticate(dbConn, middleware.AdminOnly(http.HandlerFunc(buyerHandler.DeleteBuyer))))
-
-	// Buyer Routes
-	http.Handle("/buyer/register", middleware.CORS(http.HandlerFunc(buyerHandler.Register)))
-	http.Handle("/buyer/login", middleware.CORS(http.HandlerFunc(buyerHandler.Login)))
-	http.Handle("/buyer/logout", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(buyerHandler.Logout))))
-	http.Handle("/buyer/home", middleware.CORS(middleware.Authenticate(dbConn,http.HandlerFunc(buyerHandler.Home))))
-	http.Handle("/buyer/product/", middleware.CORS(http.HandlerFunc(productHandler.GetProductDetails)))
-
-	http.Handle("/cart", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(cartHandler.GetCart))))
-	http.Handle("/cart/add", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(cartHandler.AddToCart))))
-	http.Handle("/cart/remove/", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(cartHandler.RemoveFromCart))))
-	http.Handle("/cart/update", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(cartHandler.UpdateCart))))
-
-	http.Handle("/checkout", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(cartHandler.Checkout))))
-
-	// Farmer Routes
-	http.Handle("/farmer/register", middleware.CORS(http.HandlerFunc(farmerHandler.Register)))
-	http.Handle("/farmer/login", middleware.CORS(http.HandlerFunc(farmerHandler.Login)))
-	http.Handle("/farmer/logout", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(farmerHandler.Logout))))
-	http.Handle("/farmer/dashboard", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(farmerHandler.Dashboard))))
-	http.Handle("/farmer/product/add-product", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(farmerHandler.AddProduct))))
-	http.Handle("/farmer/product/list-products", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(farmerHandler.ListProducts))))
-	http.Handle("/farmer/product/edit-product", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(farmerHandler.EditProduct))))
-	http.Handle("/farmer/product/delete-product", middleware.CORS(middleware.Authenticate(dbConn, http.HandlerFunc(farmerHandler.DeleteProduct))))
-
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	oauthManager := oauth.NewManager(dbConn, sessionStore, sessionSigner, cfg.SecureCookies(), cfg.SessionTTL, oauth.ProvidersFromConfig(cfg)...)
+
+	csrf := middleware.CSRF(middleware.CSRFOptions{
+		TrustedOrigins: cfg.CSRFTrustedOrigins,
+		Secure:         cfg.SecureCookies(),
+	})
+	cors := middleware.CORS(cfg.CORSAllowedOrigins)
+	authenticate := func(h http.Handler) http.Handler {
+		return middleware.Authenticate(sessionStore, sessionSigner, h)
 	}
+	adminOnly := middleware.AdminOnly
+
+	mux := http.NewServeMux()
+
+	mux.Handle("/favicon.ico", http.HandlerFunc(http.NotFound))
+
+	// Admin routes: server-rendered HTML, kept off the /api/v1 surface.
+	// GET routes that render a form backed by a CSRF-protected POST are
+	// chained through csrf too, so the safe-method branch actually mints
+	// the token the form submits back.
+	mux.HandleFunc("/", adminHandler.Root)
+	mux.Handle("GET /admin/register", chain(http.HandlerFunc(adminHandler.Register), csrf))
+	mux.Handle("POST /admin/register", chain(http.HandlerFunc(adminHandler.Register), csrf))
+	mux.Handle("GET /admin/login", chain(http.HandlerFunc(adminHandler.Login), csrf))
+	mux.Handle("POST /admin/login", chain(http.HandlerFunc(adminHandler.Login), csrf))
+	mux.Handle("POST /admin/logout", chain(http.HandlerFunc(adminHandler.Logout), csrf, authenticate))
+
+	mux.Handle("GET /admin/dashboard", chain(http.HandlerFunc(adminHandler.Dashboard), csrf, authenticate))
+	mux.Handle("GET /admin/dashboard/pending-farmers", chain(http.HandlerFunc(farmerHandler.ListPendingFarmers), csrf, authenticate, adminOnly))
+	mux.Handle("GET /admin/dashboard/farmer-profile", chain(http.HandlerFunc(farmerHandler.ViewFarmerProfile), csrf, authenticate, adminOnly))
+	mux.Handle("POST /admin/dashboard/approve-farmer", chain(http.HandlerFunc(farmerHandler.ApproveFarmer), csrf, authenticate, adminOnly))
+	mux.Handle("POST /admin/dashboard/reject-farmer", chain(http.HandlerFunc(farmerHandler.RejectFarmer), csrf, authenticate, adminOnly))
+
+	mux.Handle("GET /admin/users", chain(http.HandlerFunc(adminHandler.ListUsers), csrf, authenticate, adminOnly))
+
+	mux.Handle("POST /admin/users/toggle-farmer-status", chain(http.HandlerFunc(farmerHandler.ToggleFarmerStatus), csrf, authenticate, adminOnly))
+	mux.Handle("POST /admin/users/edit-farmer", chain(http.HandlerFunc(farmerHandler.EditFarmer), csrf, authenticate, adminOnly))
+	mux.Handle("POST /admin/users/delete-farmer", chain(http.HandlerFunc(farmerHandler.DeleteFarmer), csrf, authenticate, adminOnly))
+
+	mux.Handle("POST /admin/users/toggle-buyer-status", chain(http.HandlerFunc(buyerHandler.ToggleBuyerStatus), csrf, authenticate, adminOnly))
+	mux.Handle("POST /admin/users/edit-buyer", chain(http.HandlerFunc(buyerHandler.EditBuyer), csrf, authenticate, adminOnly))
+	mux.Handle("POST /admin/users/delete-buyer", chain(http.HandlerFunc(buyerHandler.DeleteBuyer), csrf, authenticate, adminOnly))
+
+	// /api/v1: the JSON surface for buyer, farmer, cart, and product
+	// operations, mounted separately from the HTML admin pages above.
+	apiMux := http.NewServeMux()
+
+	// The method-scoped routes below answer OPTIONS with 405 on their own,
+	// so CORS preflight requests are routed to cors explicitly.
+	apiMux.Handle("OPTIONS /", chain(http.NotFoundHandler(), cors))
+
+	// GET /csrf-token primes the csrf_token cookie and X-CSRF-Token header
+	// for clients with no other safe-method request to piggyback on yet,
+	// e.g. before an unauthenticated POST /buyer/register or /farmer/login.
+	apiMux.Handle("GET /csrf-token", chain(http.HandlerFunc(serveCSRFToken), cors, csrf))
+
+	apiMux.Handle("POST /buyer/register", chain(http.HandlerFunc(buyerHandler.Register), cors, csrf))
+	apiMux.Handle("POST /buyer/login", chain(http.HandlerFunc(buyerHandler.Login), cors, csrf))
+	apiMux.Handle("POST /buyer/logout", chain(http.HandlerFunc(buyerHandler.Logout), cors, csrf, authenticate))
+	apiMux.Handle("GET /buyer/home", chain(http.HandlerFunc(buyerHandler.Home), cors, csrf, authenticate))
+	apiMux.Handle("GET /buyer/product/{id}", chain(http.HandlerFunc(productHandler.GetProductDetails), cors))
+
+	apiMux.Handle("GET /buyer/oauth/{provider}/login", chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		oauthManager.LoginHandler(r.PathValue("provider"), oauth.AccountBuyer)(w, r)
+	}), cors))
+	apiMux.Handle("GET /buyer/oauth/{provider}/callback", chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		oauthManager.CallbackHandler(r.PathValue("provider"))(w, r)
+	}), cors))
+
+	apiMux.Handle("GET /cart", chain(http.HandlerFunc(cartHandler.GetCart), cors, csrf, authenticate))
+	apiMux.Handle("POST /cart/add", chain(http.HandlerFunc(cartHandler.AddToCart), cors, csrf, authenticate))
+	apiMux.Handle("DELETE /cart/remove/{productID}", chain(http.HandlerFunc(cartHandler.RemoveFromCart), cors, csrf, authenticate))
+	apiMux.Handle("POST /cart/update", chain(http.HandlerFunc(cartHandler.UpdateCart), cors, csrf, authenticate))
+
+	apiMux.Handle("POST /checkout", chain(http.HandlerFunc(cartHandler.Checkout), cors, csrf, authenticate))
+
+	apiMux.Handle("POST /farmer/register", chain(http.HandlerFunc(farmerHandler.Register), cors, csrf))
+	apiMux.Handle("POST /farmer/login", chain(http.HandlerFunc(farmerHandler.Login), cors, csrf))
+	apiMux.Handle("POST /farmer/logout", chain(http.HandlerFunc(farmerHandler.Logout), cors, csrf, authenticate))
+	apiMux.Handle("GET /farmer/dashboard", chain(http.HandlerFunc(farmerHandler.Dashboard), cors, csrf, authenticate))
+	apiMux.Handle("POST /farmer/product/add-product", chain(http.HandlerFunc(farmerHandler.AddProduct), cors, csrf, authenticate))
+	apiMux.Handle("GET /farmer/product/list-products", chain(http.HandlerFunc(farmerHandler.ListProducts), cors, csrf, authenticate))
+	apiMux.Handle("POST /farmer/product/edit-product", chain(http.HandlerFunc(farmerHandler.EditProduct), cors, csrf, authenticate))
+	apiMux.Handle("POST /farmer/product/delete-product", chain(http.HandlerFunc(farmerHandler.DeleteProduct), cors, csrf, authenticate))
 
-	log.Printf("Server starting on port %s", port)
-	err = http.ListenAndServe(":"+port, nil)
+	apiMux.Handle("GET /farmer/oauth/{provider}/login", chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		oauthManager.LoginHandler(r.PathValue("provider"), oauth.AccountFarmer)(w, r)
+	}), cors))
+	apiMux.Handle("GET /farmer/oauth/{provider}/callback", chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		oauthManager.CallbackHandler(r.PathValue("provider"))(w, r)
+	}), cors))
+
+	apiMux.HandleFunc("GET /openapi.json", serveOpenAPIJSON)
+	apiMux.HandleFunc("GET /docs", serveSwaggerUI)
+
+	mux.Handle("/api/v1/", http.StripPrefix("/api/v1", apiMux))
+
+	srv := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      15 * time.Second,
+	}
+
+	go func() {
+		log.Printf("Server starting on port %s", cfg.Port)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Server failed to start: %v", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	log.Println("Shutting down server...")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatalf("Server forced to shut down: %v", err)
+	}
+	log.Println("Server exited cleanly")
+}
+
+// chain applies middleware to h in the order given, so chain(h, a, b) runs
+// as a(b(h)) — the first middleware listed is the outermost.
+func chain(h http.Handler, mws ...func(http.Handler) http.Handler) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// serveOpenAPIJSON serves backend/api/openapi.yaml as JSON, so frontend
+// developers have a single source of truth regardless of which format a
+// given tool prefers.
+func serveOpenAPIJSON(w http.ResponseWriter, r *http.Request) {
+	raw, err := os.ReadFile("api/openapi.yaml")
 	if err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to read openapi spec")
+		return
+	}
+
+	var spec any
+	if err := yaml.Unmarshal(raw, &spec); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to parse openapi spec")
+		return
 	}
+
+	httputil.WriteJSON(w, http.StatusOK, spec)
+}
+
+// serveCSRFToken responds 204, relying entirely on the csrf middleware
+// ahead of it to have already minted the csrf_token cookie and
+// X-CSRF-Token response header. It exists for clients (e.g. a registration
+// or login form) that have no other safe-method request to prime the
+// token from first.
+func serveCSRFToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveSwaggerUI renders a minimal Swagger UI page pointed at
+// /api/v1/openapi.json.
+func serveSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprint(w, `<!DOCTYPE html>
+<html>
+<head>
+  <title>FarmerMarketSystem API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => SwaggerUIBundle({ url: "/api/v1/openapi.json", dom_id: "#swagger-ui" })
+  </script>
+</body>
+</html>`)
 }
 
 func parseTemplates(pattern string) (map[string]*template.Template, error) {
@@ -0,0 +1,243 @@
+// Package oauth implements OAuth2/OIDC login for buyers and farmers,
+// alongside the existing password-based auth.
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/session"
+)
+
+// AccountKind selects which table an OAuth login provisions into.
+type AccountKind string
+
+const (
+	AccountBuyer  AccountKind = "buyer"
+	AccountFarmer AccountKind = "farmer"
+)
+
+const stateCookieName = "oauth_state"
+
+// UserInfo is the subset of claims we need from a provider's userinfo
+// endpoint, normalized across Google and GitHub.
+type UserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider wires an oauth2.Config to a function that turns an access token
+// into a normalized UserInfo.
+type Provider struct {
+	Name          string
+	Config        *oauth2.Config
+	FetchUserInfo func(ctx context.Context, token *oauth2.Token) (*UserInfo, error)
+}
+
+// Manager holds the configured providers and issues sessions on successful
+// login, reusing the same session.Store and signer as
+// middleware.Authenticate.
+type Manager struct {
+	providers     map[string]*Provider
+	dbConn        *db.DB
+	sessions      session.Store
+	signer        *session.Signer
+	secureCookies bool
+	sessionTTL    time.Duration
+}
+
+// NewManager builds a Manager from the given providers, keyed by name
+// (e.g. "google", "github"). secureCookies should come from
+// config.Config.SecureCookies, and sessionTTL from config.Config.SessionTTL
+// so OAuth-issued sessions expire on the same schedule as password-based
+// ones.
+func NewManager(dbConn *db.DB, sessions session.Store, signer *session.Signer, secureCookies bool, sessionTTL time.Duration, providers ...*Provider) *Manager {
+	m := &Manager{
+		providers:     make(map[string]*Provider, len(providers)),
+		dbConn:        dbConn,
+		sessions:      sessions,
+		signer:        signer,
+		secureCookies: secureCookies,
+		sessionTTL:    sessionTTL,
+	}
+	for _, p := range providers {
+		m.providers[p.Name] = p
+	}
+	return m
+}
+
+func (m *Manager) provider(name string) (*Provider, error) {
+	p, ok := m.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("oauth: unknown provider %q", name)
+	}
+	return p, nil
+}
+
+// LoginHandler redirects the user to the named provider's consent screen,
+// stashing a random state value in a short-lived cookie for CSRF protection
+// of the OAuth flow itself.
+func (m *Manager) LoginHandler(providerName string, kind AccountKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, err := m.provider(providerName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		state, err := randomState()
+		if err != nil {
+			http.Error(w, "failed to start oauth flow", http.StatusInternalServerError)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     stateCookieName,
+			Value:    string(kind) + ":" + state,
+			Path:     "/",
+			Expires:  time.Now().Add(10 * time.Minute),
+			HttpOnly: true,
+			Secure:   m.secureCookies,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		http.Redirect(w, r, p.Config.AuthCodeURL(state), http.StatusFound)
+	}
+}
+
+// CallbackHandler exchanges the authorization code, fetches the provider's
+// userinfo, resolves or provisions the local account, and issues a session
+// cookie through the shared session store.
+func (m *Manager) CallbackHandler(providerName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, err := m.provider(providerName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		kind, err := m.verifyState(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			http.Error(w, "missing authorization code", http.StatusBadRequest)
+			return
+		}
+
+		token, err := p.Config.Exchange(r.Context(), code)
+		if err != nil {
+			http.Error(w, "failed to exchange authorization code", http.StatusBadGateway)
+			return
+		}
+
+		info, err := p.FetchUserInfo(r.Context(), token)
+		if err != nil || info.Email == "" || !info.EmailVerified {
+			http.Error(w, "failed to verify oauth identity", http.StatusBadGateway)
+			return
+		}
+
+		userID, err := m.resolveAccount(r, kind, providerName, info)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		sess, err := session.New(m.signer, userID, string(kind), m.sessionTTL)
+		if err != nil {
+			http.Error(w, "failed to create session", http.StatusInternalServerError)
+			return
+		}
+		if err := m.sessions.Save(r.Context(), sess); err != nil {
+			http.Error(w, "failed to create session", http.StatusInternalServerError)
+			return
+		}
+		session.SetCookie(w, m.signer, sess, m.secureCookies)
+
+		switch kind {
+		case AccountFarmer:
+			http.Redirect(w, r, "/api/v1/farmer/dashboard", http.StatusFound)
+		default:
+			http.Redirect(w, r, "/api/v1/buyer/home", http.StatusFound)
+		}
+	}
+}
+
+// resolveAccount links the provider identity to an existing account by
+// verified email, or auto-provisions a new buyer. Farmers still require
+// admin approval, so an unrecognized farmer email is rejected rather than
+// silently provisioned.
+func (m *Manager) resolveAccount(r *http.Request, kind AccountKind, providerName string, info *UserInfo) (int64, error) {
+	if userID, err := m.dbConn.FindUserIdentity(r.Context(), providerName, info.Subject, string(kind)); err == nil {
+		return userID, nil
+	}
+
+	switch kind {
+	case AccountBuyer:
+		userID, err := m.dbConn.GetBuyerIDByEmail(r.Context(), info.Email)
+		if errors.Is(err, db.ErrNotFound) {
+			userID, err = m.dbConn.CreateBuyer(r.Context(), info.Email, info.Name)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if err := m.dbConn.LinkUserIdentity(r.Context(), userID, "buyer", providerName, info.Subject); err != nil {
+			return 0, err
+		}
+		return userID, nil
+
+	case AccountFarmer:
+		userID, err := m.dbConn.GetFarmerIDByEmail(r.Context(), info.Email)
+		if err != nil {
+			return 0, fmt.Errorf("oauth: no approved farmer account for %s: %w", info.Email, err)
+		}
+		if err := m.dbConn.LinkUserIdentity(r.Context(), userID, "farmer", providerName, info.Subject); err != nil {
+			return 0, err
+		}
+		return userID, nil
+
+	default:
+		return 0, fmt.Errorf("oauth: unsupported account kind %q", kind)
+	}
+}
+
+func (m *Manager) verifyState(r *http.Request) (AccountKind, error) {
+	cookie, err := r.Cookie(stateCookieName)
+	if err != nil {
+		return "", errors.New("missing oauth state cookie")
+	}
+	kind, state, ok := splitState(cookie.Value)
+	if !ok || r.URL.Query().Get("state") != state {
+		return "", errors.New("oauth state mismatch")
+	}
+	return AccountKind(kind), nil
+}
+
+func splitState(v string) (kind, state string, ok bool) {
+	for i := 0; i < len(v); i++ {
+		if v[i] == ':' {
+			return v[:i], v[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+	"golang.org/x/oauth2/google"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/config"
+)
+
+// ProvidersFromConfig builds the Google and GitHub providers from cfg. A
+// provider is skipped if its client ID is unset, so deployments only need
+// to configure the providers they actually use.
+func ProvidersFromConfig(cfg *config.Config) []*Provider {
+	var providers []*Provider
+	if p := googleProviderFromConfig(cfg); p != nil {
+		providers = append(providers, p)
+	}
+	if p := githubProviderFromConfig(cfg); p != nil {
+		providers = append(providers, p)
+	}
+	return providers
+}
+
+func googleProviderFromConfig(cfg *config.Config) *Provider {
+	if cfg.OAuthGoogleClientID == "" {
+		return nil
+	}
+
+	return &Provider{
+		Name: "google",
+		Config: &oauth2.Config{
+			ClientID:     cfg.OAuthGoogleClientID,
+			ClientSecret: cfg.OAuthGoogleClientSecret,
+			RedirectURL:  cfg.OAuthGoogleRedirectURL,
+			Scopes:       []string{"openid", "email", "profile"},
+			Endpoint:     google.Endpoint,
+		},
+		FetchUserInfo: fetchGoogleUserInfo,
+	}
+}
+
+func fetchGoogleUserInfo(ctx context.Context, token *oauth2.Token) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.googleapis.com/oauth2/v3/userinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	token.SetAuthHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oauth: google userinfo returned %s", resp.Status)
+	}
+
+	var payload struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	return &UserInfo{
+		Subject:       payload.Sub,
+		Email:         payload.Email,
+		EmailVerified: payload.EmailVerified,
+		Name:          payload.Name,
+	}, nil
+}
+
+func githubProviderFromConfig(cfg *config.Config) *Provider {
+	if cfg.OAuthGithubClientID == "" {
+		return nil
+	}
+
+	return &Provider{
+		Name: "github",
+		Config: &oauth2.Config{
+			ClientID:     cfg.OAuthGithubClientID,
+			ClientSecret: cfg.OAuthGithubClientSecret,
+			RedirectURL:  cfg.OAuthGithubRedirectURL,
+			Scopes:       []string{"read:user", "user:email"},
+			Endpoint:     github.Endpoint,
+		},
+		FetchUserInfo: fetchGitHubUserInfo,
+	}
+}
+
+// GitHub's /user endpoint omits the email unless it is public, so we fall
+// back to the dedicated emails endpoint and take the primary verified one.
+func fetchGitHubUserInfo(ctx context.Context, token *oauth2.Token) (*UserInfo, error) {
+	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(token))
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	if err := getJSON(client, "https://api.github.com/user", &user); err != nil {
+		return nil, err
+	}
+
+	email, verified := user.Email, user.Email != ""
+	if !verified {
+		var emails []struct {
+			Email    string `json:"email"`
+			Primary  bool   `json:"primary"`
+			Verified bool   `json:"verified"`
+		}
+		if err := getJSON(client, "https://api.github.com/user/emails", &emails); err != nil {
+			return nil, err
+		}
+		for _, e := range emails {
+			if e.Primary && e.Verified {
+				email, verified = e.Email, true
+				break
+			}
+		}
+	}
+
+	return &UserInfo{
+		Subject:       fmt.Sprintf("%d", user.ID),
+		Email:         email,
+		EmailVerified: verified,
+		Name:          user.Name,
+	}, nil
+}
+
+func getJSON(client *http.Client, url string, v any) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oauth: %s returned %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,106 @@
+// Package config centralizes environment-driven settings so they are
+// declared once, validated at startup, and typed for every caller instead
+// of being read ad hoc via os.Getenv scattered across the codebase.
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds every environment-driven setting the server needs. Fields
+// are populated by Load via envconfig struct tags.
+type Config struct {
+	DatabaseURL string `envconfig:"DATABASE_URL" required:"true"`
+	Port        string `envconfig:"PORT" default:"8080"`
+	LogLevel    string `envconfig:"LOG_LEVEL" default:"info"`
+	Env         string `envconfig:"APP_ENV" default:"development"`
+
+	SessionStore  string        `envconfig:"SESSION_STORE" default:"postgres"`
+	SessionSecret string        `envconfig:"SESSION_SECRET" required:"true"`
+	SessionTTL    time.Duration `envconfig:"SESSION_TTL" default:"24h"`
+	RedisAddr     string        `envconfig:"REDIS_ADDR" default:"localhost:6379"`
+
+	CSRFTrustedOrigins []string `envconfig:"CSRF_TRUSTED_ORIGINS" default:"http://localhost:3000"`
+
+	CORSAllowedOrigins []string `envconfig:"CORS_ALLOWED_ORIGINS" default:"http://localhost:3000"`
+
+	OAuthGoogleClientID     string `envconfig:"OAUTH_GOOGLE_CLIENT_ID"`
+	OAuthGoogleClientSecret string `envconfig:"OAUTH_GOOGLE_CLIENT_SECRET"`
+	OAuthGoogleRedirectURL  string `envconfig:"OAUTH_GOOGLE_REDIRECT_URL"`
+	OAuthGithubClientID     string `envconfig:"OAUTH_GITHUB_CLIENT_ID"`
+	OAuthGithubClientSecret string `envconfig:"OAUTH_GITHUB_CLIENT_SECRET"`
+	OAuthGithubRedirectURL  string `envconfig:"OAUTH_GITHUB_REDIRECT_URL"`
+
+	TLSCertFile string `envconfig:"TLS_CERT_FILE"`
+	TLSKeyFile  string `envconfig:"TLS_KEY_FILE"`
+}
+
+// Load reads .env (if present, for local development) and then populates a
+// Config from the environment, failing fast with a clear error naming
+// every missing required field.
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !isNotExist(err) {
+		return nil, fmt.Errorf("config: failed to load .env: %w", err)
+	}
+
+	var missing []string
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("envconfig")
+		if t.Field(i).Tag.Get("required") == "true" {
+			if _, ok := os.LookupEnv(key); !ok {
+				missing = append(missing, key)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("config: missing required settings: %s", strings.Join(missing, ", "))
+	}
+
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+func isNotExist(err error) bool {
+	return os.IsNotExist(err)
+}
+
+// Summary returns a human-readable, secret-redacted rendering of cfg
+// suitable for logging at startup.
+func (cfg *Config) Summary() string {
+	return fmt.Sprintf(
+		"env=%s port=%s log_level=%s session_store=%s session_ttl=%s session_secret=%s "+
+			"csrf_trusted_origins=%v cors_allowed_origins=%v "+
+			"oauth_google_client_id=%s oauth_github_client_id=%s tls=%v",
+		cfg.Env, cfg.Port, cfg.LogLevel, cfg.SessionStore, cfg.SessionTTL, redact(cfg.SessionSecret),
+		cfg.CSRFTrustedOrigins, cfg.CORSAllowedOrigins,
+		redact(cfg.OAuthGoogleClientID), redact(cfg.OAuthGithubClientID),
+		cfg.TLSCertFile != "" && cfg.TLSKeyFile != "",
+	)
+}
+
+// SecureCookies reports whether cookies (CSRF, session, OAuth state)
+// should carry the Secure attribute. It is false only in local development
+// (APP_ENV=development, the default), since browsers silently refuse to
+// set or send Secure cookies over plain http://localhost.
+func (cfg *Config) SecureCookies() bool {
+	return cfg.Env != "development"
+}
+
+func redact(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return "***redacted***"
+}
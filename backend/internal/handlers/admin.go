@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/httputil"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/session"
+)
+
+// AdminHandler serves the server-rendered admin pages: registration, login,
+// and the dashboard used to manage farmer and buyer accounts. It never
+// answers /api/v1, so it only ever renders HTML.
+type AdminHandler struct {
+	dbConn        *db.DB
+	templates     map[string]*template.Template
+	sessions      session.Store
+	signer        *session.Signer
+	secureCookies bool
+	sessionTTL    time.Duration
+}
+
+// NewAdminHandler builds an AdminHandler backed by dbConn, rendering the
+// given parsed templates. sessions and signer issue and verify admin
+// sessions the same way oauth.Manager does for buyers and farmers, and
+// sessionTTL should come from config.Config.SessionTTL so SESSION_TTL
+// governs admin sessions the same as buyer/farmer ones.
+func NewAdminHandler(dbConn *db.DB, templates map[string]*template.Template, sessions session.Store, signer *session.Signer, secureCookies bool, sessionTTL time.Duration) *AdminHandler {
+	return &AdminHandler{
+		dbConn:        dbConn,
+		templates:     templates,
+		sessions:      sessions,
+		signer:        signer,
+		secureCookies: secureCookies,
+		sessionTTL:    sessionTTL,
+	}
+}
+
+// Root redirects to the dashboard for a logged-in admin, or the login page
+// otherwise.
+func (h *AdminHandler) Root(w http.ResponseWriter, r *http.Request) {
+	if id, err := session.IDFromRequest(r, h.signer); err == nil {
+		if sess, err := h.sessions.Get(r.Context(), id); err == nil && sess.Role == "admin" {
+			http.Redirect(w, r, "/admin/dashboard", http.StatusFound)
+			return
+		}
+	}
+	http.Redirect(w, r, "/admin/login", http.StatusFound)
+}
+
+// Register renders the admin registration form on GET and creates the
+// account on POST.
+func (h *AdminHandler) Register(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		render(w, r, h.templates, "admin_register", nil)
+		return
+	}
+
+	email := r.FormValue("email")
+	name := r.FormValue("name")
+	password := r.FormValue("password")
+	if email == "" || password == "" {
+		render(w, r, h.templates, "admin_register", map[string]any{"Error": "email and password are required"})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to hash password")
+		return
+	}
+
+	if _, err := h.dbConn.CreateAdmin(r.Context(), email, name, string(hash)); err != nil {
+		render(w, r, h.templates, "admin_register", map[string]any{"Error": "could not create account"})
+		return
+	}
+
+	http.Redirect(w, r, "/admin/login", http.StatusFound)
+}
+
+// Login renders the admin login form on GET and issues a session on POST.
+func (h *AdminHandler) Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		render(w, r, h.templates, "admin_login", nil)
+		return
+	}
+
+	email := r.FormValue("email")
+	password := r.FormValue("password")
+
+	admin, err := h.dbConn.GetAdminByEmail(r.Context(), email)
+	if err != nil || bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(password)) != nil {
+		render(w, r, h.templates, "admin_login", map[string]any{"Error": "invalid email or password"})
+		return
+	}
+
+	sess, err := session.New(h.signer, admin.ID, "admin", h.sessionTTL)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to create session")
+		return
+	}
+	if err := h.sessions.Save(r.Context(), sess); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to create session")
+		return
+	}
+	session.SetCookie(w, h.signer, sess, h.secureCookies)
+
+	http.Redirect(w, r, "/admin/dashboard", http.StatusFound)
+}
+
+// Logout destroys the current admin session and clears the cookie.
+func (h *AdminHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	if err := h.sessions.Destroy(r.Context(), sess.ID); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to log out")
+		return
+	}
+	session.ClearCookie(w, h.secureCookies)
+	http.Redirect(w, r, "/admin/login", http.StatusFound)
+}
+
+// Dashboard renders the admin landing page.
+func (h *AdminHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	render(w, r, h.templates, "admin_dashboard", nil)
+}
+
+// ListUsers renders the combined farmer/buyer account management page.
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	buyers, err := h.dbConn.ListBuyers(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to list buyers")
+		return
+	}
+	farmers, err := h.dbConn.ListFarmers(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to list farmers")
+		return
+	}
+	render(w, r, h.templates, "admin_users", map[string]any{"Buyers": buyers, "Farmers": farmers})
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/httputil"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/session"
+)
+
+// BuyerHandler serves two surfaces with the same underlying data:
+//   - HTML admin pages (routed from mux) for suspending, editing, and
+//     removing buyer accounts, rendered with templates.
+//   - The /api/v1 JSON surface (routed from apiMux) buyers use to manage
+//     their own account and browse the marketplace.
+type BuyerHandler struct {
+	dbConn        *db.DB
+	templates     map[string]*template.Template
+	sessions      session.Store
+	signer        *session.Signer
+	secureCookies bool
+	sessionTTL    time.Duration
+}
+
+// NewBuyerHandler builds a BuyerHandler backed by dbConn, rendering the
+// given parsed templates for its admin-facing methods. sessionTTL should
+// come from config.Config.SessionTTL, matching the TTL oauth.Manager uses
+// for OAuth-issued buyer sessions.
+func NewBuyerHandler(dbConn *db.DB, templates map[string]*template.Template, sessions session.Store, signer *session.Signer, secureCookies bool, sessionTTL time.Duration) *BuyerHandler {
+	return &BuyerHandler{
+		dbConn:        dbConn,
+		templates:     templates,
+		sessions:      sessions,
+		signer:        signer,
+		secureCookies: secureCookies,
+		sessionTTL:    sessionTTL,
+	}
+}
+
+// --- Admin-facing HTML methods (mux) ---
+
+// ToggleBuyerStatus flips a buyer account between active and suspended.
+func (h *BuyerHandler) ToggleBuyerStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid buyer id")
+		return
+	}
+	if err := h.dbConn.ToggleBuyerStatus(r.Context(), id); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to update buyer status")
+		return
+	}
+	http.Redirect(w, r, "/admin/users", http.StatusFound)
+}
+
+// EditBuyer updates a buyer's profile fields from the admin edit form.
+func (h *BuyerHandler) EditBuyer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid buyer id")
+		return
+	}
+	if err := h.dbConn.UpdateBuyer(r.Context(), id, r.FormValue("name"), r.FormValue("email")); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to update buyer")
+		return
+	}
+	http.Redirect(w, r, "/admin/users", http.StatusFound)
+}
+
+// DeleteBuyer permanently removes a buyer account.
+func (h *BuyerHandler) DeleteBuyer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid buyer id")
+		return
+	}
+	if err := h.dbConn.DeleteBuyer(r.Context(), id); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to delete buyer")
+		return
+	}
+	http.Redirect(w, r, "/admin/users", http.StatusFound)
+}
+
+// --- /api/v1 JSON methods (apiMux) ---
+
+type buyerRegisterRequest struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// Register creates a buyer account.
+func (h *BuyerHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req buyerRegisterRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Email == "" || req.Password == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "", "email and password are required")
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to hash password")
+		return
+	}
+
+	if _, err := h.dbConn.CreateBuyerWithPassword(r.Context(), req.Email, req.Name, string(hash)); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not register buyer")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, map[string]string{"status": "registered"})
+}
+
+type buyerLoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Login authenticates a buyer and issues a session cookie.
+func (h *BuyerHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req buyerLoginRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	buyer, err := h.dbConn.GetBuyerByEmail(r.Context(), req.Email)
+	if err != nil || bcrypt.CompareHashAndPassword([]byte(buyer.PasswordHash), []byte(req.Password)) != nil {
+		httputil.WriteError(w, http.StatusUnauthorized, "", "invalid credentials")
+		return
+	}
+
+	sess, err := session.New(h.signer, buyer.ID, "buyer", h.sessionTTL)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to create session")
+		return
+	}
+	if err := h.sessions.Save(r.Context(), sess); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to create session")
+		return
+	}
+	session.SetCookie(w, h.signer, sess, h.secureCookies)
+
+	httputil.WriteJSON(w, http.StatusOK, map[string]string{"status": "logged in"})
+}
+
+// Logout destroys the current buyer session.
+func (h *BuyerHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	if err := h.sessions.Destroy(r.Context(), sess.ID); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to log out")
+		return
+	}
+	session.ClearCookie(w, h.secureCookies)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Home returns the buyer's home feed.
+func (h *BuyerHandler) Home(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.dbConn.ListAvailableProducts(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to load home feed")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, map[string]any{
+		"buyerId":  sess.UserID,
+		"products": products,
+	})
+}
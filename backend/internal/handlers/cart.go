@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/httputil"
+)
+
+// CartHandler serves the buyer's cart and checkout on /api/v1. It has no
+// HTML surface, so it carries no template dependency.
+type CartHandler struct {
+	dbConn *db.DB
+}
+
+// NewCartHandler builds a CartHandler backed by dbConn.
+func NewCartHandler(dbConn *db.DB) *CartHandler {
+	return &CartHandler{dbConn: dbConn}
+}
+
+// GetCart returns the current buyer's cart.
+func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	cart, err := h.dbConn.GetCart(r.Context(), sess.UserID)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to load cart")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, cart)
+}
+
+type cartItemRequest struct {
+	ProductID int64 `json:"productId"`
+	Quantity  int   `json:"quantity"`
+}
+
+// AddToCart adds a product to the current buyer's cart.
+func (h *CartHandler) AddToCart(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	var req cartItemRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.dbConn.AddCartItem(r.Context(), sess.UserID, req.ProductID, req.Quantity); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not add item to cart")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// RemoveFromCart removes a product from the current buyer's cart.
+func (h *CartHandler) RemoveFromCart(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	productID, err := strconv.ParseInt(r.PathValue("productID"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid product id")
+		return
+	}
+	if err := h.dbConn.RemoveCartItem(r.Context(), sess.UserID, productID); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not remove item from cart")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateCart changes the quantity of a product already in the current
+// buyer's cart.
+func (h *CartHandler) UpdateCart(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	var req cartItemRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.dbConn.UpdateCartItem(r.Context(), sess.UserID, req.ProductID, req.Quantity); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not update cart")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Checkout converts the current buyer's cart into an order.
+func (h *CartHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	order, err := h.dbConn.Checkout(r.Context(), sess.UserID)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not check out cart")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, order)
+}
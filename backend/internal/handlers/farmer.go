@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/httputil"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/session"
+)
+
+// FarmerHandler serves two surfaces with the same underlying data:
+//   - HTML admin pages (routed from mux) for approving, editing, and
+//     removing farmer accounts, rendered with templates.
+//   - The /api/v1 JSON surface (routed from apiMux) farmers use to manage
+//     their own account and product listings.
+type FarmerHandler struct {
+	dbConn        *db.DB
+	templates     map[string]*template.Template
+	sessions      session.Store
+	signer        *session.Signer
+	secureCookies bool
+	sessionTTL    time.Duration
+}
+
+// NewFarmerHandler builds a FarmerHandler backed by dbConn, rendering the
+// given parsed templates for its admin-facing methods. sessionTTL should
+// come from config.Config.SessionTTL, matching the TTL oauth.Manager uses
+// for OAuth-issued farmer sessions.
+func NewFarmerHandler(dbConn *db.DB, templates map[string]*template.Template, sessions session.Store, signer *session.Signer, secureCookies bool, sessionTTL time.Duration) *FarmerHandler {
+	return &FarmerHandler{
+		dbConn:        dbConn,
+		templates:     templates,
+		sessions:      sessions,
+		signer:        signer,
+		secureCookies: secureCookies,
+		sessionTTL:    sessionTTL,
+	}
+}
+
+// --- Admin-facing HTML methods (mux) ---
+
+// ListPendingFarmers renders farmer accounts awaiting approval.
+func (h *FarmerHandler) ListPendingFarmers(w http.ResponseWriter, r *http.Request) {
+	farmers, err := h.dbConn.ListPendingFarmers(r.Context())
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to list pending farmers")
+		return
+	}
+	render(w, r, h.templates, "admin_pending_farmers", map[string]any{"Farmers": farmers})
+}
+
+// ViewFarmerProfile renders a single farmer's profile for admin review.
+func (h *FarmerHandler) ViewFarmerProfile(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid farmer id")
+		return
+	}
+	farmer, err := h.dbConn.GetFarmerByID(r.Context(), id)
+	if err != nil {
+		httputil.WriteError(w, http.StatusNotFound, "", "farmer not found")
+		return
+	}
+	render(w, r, h.templates, "admin_farmer_profile", map[string]any{"Farmer": farmer})
+}
+
+// ApproveFarmer marks a pending farmer account as approved.
+func (h *FarmerHandler) ApproveFarmer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid farmer id")
+		return
+	}
+	if err := h.dbConn.ApproveFarmer(r.Context(), id); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to approve farmer")
+		return
+	}
+	http.Redirect(w, r, "/admin/dashboard/pending-farmers", http.StatusFound)
+}
+
+// RejectFarmer removes a pending farmer account.
+func (h *FarmerHandler) RejectFarmer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid farmer id")
+		return
+	}
+	if err := h.dbConn.DeleteFarmer(r.Context(), id); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to reject farmer")
+		return
+	}
+	http.Redirect(w, r, "/admin/dashboard/pending-farmers", http.StatusFound)
+}
+
+// ToggleFarmerStatus flips a farmer account between active and suspended.
+func (h *FarmerHandler) ToggleFarmerStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid farmer id")
+		return
+	}
+	if err := h.dbConn.ToggleFarmerStatus(r.Context(), id); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to update farmer status")
+		return
+	}
+	http.Redirect(w, r, "/admin/users", http.StatusFound)
+}
+
+// EditFarmer updates a farmer's profile fields from the admin edit form.
+func (h *FarmerHandler) EditFarmer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid farmer id")
+		return
+	}
+	if err := h.dbConn.UpdateFarmer(r.Context(), id, r.FormValue("name"), r.FormValue("email")); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to update farmer")
+		return
+	}
+	http.Redirect(w, r, "/admin/users", http.StatusFound)
+}
+
+// DeleteFarmer permanently removes a farmer account.
+func (h *FarmerHandler) DeleteFarmer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid farmer id")
+		return
+	}
+	if err := h.dbConn.DeleteFarmer(r.Context(), id); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to delete farmer")
+		return
+	}
+	http.Redirect(w, r, "/admin/users", http.StatusFound)
+}
+
+// --- /api/v1 JSON methods (apiMux) ---
+
+type farmerRegisterRequest struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// Register creates a farmer account pending admin approval.
+func (h *FarmerHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req farmerRegisterRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Email == "" || req.Password == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "", "email and password are required")
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to hash password")
+		return
+	}
+
+	if _, err := h.dbConn.CreateFarmer(r.Context(), req.Email, req.Name, string(hash)); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not register farmer")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, map[string]string{"status": "pending approval"})
+}
+
+type farmerLoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Login authenticates an approved farmer and issues a session cookie.
+func (h *FarmerHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req farmerLoginRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	farmer, err := h.dbConn.GetFarmerByEmail(r.Context(), req.Email)
+	if err != nil || !farmer.Approved || bcrypt.CompareHashAndPassword([]byte(farmer.PasswordHash), []byte(req.Password)) != nil {
+		httputil.WriteError(w, http.StatusForbidden, "", "invalid credentials or account pending approval")
+		return
+	}
+
+	sess, err := session.New(h.signer, farmer.ID, "farmer", h.sessionTTL)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to create session")
+		return
+	}
+	if err := h.sessions.Save(r.Context(), sess); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to create session")
+		return
+	}
+	session.SetCookie(w, h.signer, sess, h.secureCookies)
+
+	httputil.WriteJSON(w, http.StatusOK, map[string]string{"status": "logged in"})
+}
+
+// Logout destroys the current farmer session.
+func (h *FarmerHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	if err := h.sessions.Destroy(r.Context(), sess.ID); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to log out")
+		return
+	}
+	session.ClearCookie(w, h.secureCookies)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Dashboard returns a summary of the current farmer's account and products.
+func (h *FarmerHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	farmer, err := h.dbConn.GetFarmerByID(r.Context(), sess.UserID)
+	if err != nil {
+		httputil.WriteError(w, http.StatusNotFound, "", "farmer not found")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, farmer)
+}
+
+type productRequest struct {
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
+}
+
+// AddProduct creates a new product listing for the current farmer.
+func (h *FarmerHandler) AddProduct(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	var req productRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	id, err := h.dbConn.CreateProduct(r.Context(), sess.UserID, req.Name, req.Price, req.Quantity)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not create product")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, map[string]int64{"id": id})
+}
+
+// ListProducts returns the current farmer's product listings.
+func (h *FarmerHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	products, err := h.dbConn.ListProductsByFarmer(r.Context(), sess.UserID)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to list products")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, products)
+}
+
+// EditProduct updates a product owned by the current farmer.
+func (h *FarmerHandler) EditProduct(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		ID int64 `json:"id"`
+		productRequest
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.dbConn.UpdateProduct(r.Context(), req.ID, sess.UserID, req.Name, req.Price, req.Quantity); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not update product")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, map[string]string{"status": "updated"})
+}
+
+// DeleteProduct removes a product owned by the current farmer.
+func (h *FarmerHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	sess, ok := currentSession(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		ID int64 `json:"id"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := h.dbConn.DeleteProduct(r.Context(), req.ID, sess.UserID); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "could not delete product")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
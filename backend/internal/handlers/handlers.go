@@ -0,0 +1,57 @@
+// Package handlers implements the request handlers wired up in cmd/main.go.
+// AdminHandler is server-rendered HTML only. FarmerHandler and BuyerHandler
+// serve both: their HTML-admin methods (routed from mux) render the admin
+// templates, while their api methods (routed from apiMux) encode JSON.
+// ProductHandler and CartHandler sit entirely behind /api/v1 and only ever
+// encode JSON.
+package handlers
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/httputil"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/middleware"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/session"
+)
+
+// render executes the named template with data, adding the current
+// request's CSRF token under "csrfField" so forms can submit it back.
+func render(w http.ResponseWriter, r *http.Request, templates map[string]*template.Template, name string, data map[string]any) {
+	tmpl, ok := templates[name]
+	if !ok {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "template not found: "+name)
+		return
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+	data["csrfField"] = middleware.TemplateField(r)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(w, data); err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "", "failed to render template")
+	}
+}
+
+// decodeJSON reads and decodes the request body into v, writing a 400
+// problem+json response and returning false on failure.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid request body")
+		return false
+	}
+	return true
+}
+
+// currentSession returns the session attached by middleware.Authenticate,
+// writing a 401 problem+json response and returning false if absent.
+func currentSession(w http.ResponseWriter, r *http.Request) (*session.Session, bool) {
+	sess, ok := session.FromContext(r.Context())
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "", "authentication required")
+		return nil, false
+	}
+	return sess, true
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/httputil"
+)
+
+// ProductHandler serves public, read-only product data on /api/v1. It has
+// no HTML surface, so it carries no template dependency.
+type ProductHandler struct {
+	dbConn *db.DB
+}
+
+// NewProductHandler builds a ProductHandler backed by dbConn.
+func NewProductHandler(dbConn *db.DB) *ProductHandler {
+	return &ProductHandler{dbConn: dbConn}
+}
+
+// GetProductDetails returns a single product by its path ID.
+func (h *ProductHandler) GetProductDetails(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "", "invalid product id")
+		return
+	}
+
+	product, err := h.dbConn.GetProduct(r.Context(), id)
+	if err != nil {
+		httputil.WriteError(w, http.StatusNotFound, "", "product not found")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, product)
+}
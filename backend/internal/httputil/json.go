@@ -0,0 +1,42 @@
+// Package httputil provides the shared JSON response helpers used by the
+// /api/v1 handlers, so every endpoint encodes success and error bodies the
+// same way.
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem+json error body.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// WriteJSON encodes v as the response body with the given status code.
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// WriteError writes an RFC 7807 problem+json body for status, using
+// http.StatusText(status) as the title unless title is provided.
+func WriteError(w http.ResponseWriter, status int, title, detail string) {
+	if title == "" {
+		title = http.StatusText(status)
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Problem{
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	})
+}
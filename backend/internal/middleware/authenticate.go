@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/session"
+)
+
+// Authenticate verifies the signed session_id cookie against sessionStore,
+// attaching the resolved session to the request context (retrievable via
+// session.FromContext) before calling next. It responds 401 if the cookie
+// is missing, unsigned, or the session it names has expired or been
+// destroyed.
+func Authenticate(sessionStore session.Store, signer *session.Signer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := session.IDFromRequest(r, signer)
+		if err != nil {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		sess, err := sessionStore.Get(r.Context(), id)
+		if err != nil {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(session.NewContext(r.Context(), sess)))
+	})
+}
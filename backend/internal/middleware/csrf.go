@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	csrfCookieName  = "csrf_token"
+	csrfHeaderName  = "X-CSRF-Token"
+	csrfFormField   = "csrf_token"
+	csrfSecretBytes = 32
+)
+
+// CSRFOptions configures the CSRF middleware. TrustedOrigins lists the
+// scheme+host values (e.g. "https://market.example.com") allowed to make
+// unsafe requests; the request's own Origin/Referer must match one of them.
+// An empty TrustedOrigins skips the origin check.
+type CSRFOptions struct {
+	TrustedOrigins []string
+	CookieDomain   string
+	Secure         bool
+	FailureHandler http.Handler
+}
+
+// csrfContextKey is used to stash the masked token for template helpers.
+type csrfContextKey struct{}
+
+// CSRF returns middleware implementing double-submit CSRF protection.
+//
+// If the request has no valid csrf_token cookie, a new random secret is
+// stored in an HttpOnly, SameSite=Lax cookie (Secure when opts.Secure is
+// set). On every request it masks that secret with a fresh pad and exposes
+// the masked token via the X-CSRF-Token response header. On safe methods
+// (GET/HEAD/OPTIONS/TRACE) it stashes the masked token in the request
+// context so templates can render it with {{ .csrfField }}. On unsafe
+// methods it reads the masked token from the X-CSRF-Token header or the
+// csrf_token form field, unmasks it, and compares it against the cookie's
+// secret in constant time. It also requires Origin (falling back to
+// Referer) to match one of opts.TrustedOrigins, if any are configured.
+func CSRF(opts CSRFOptions) func(http.Handler) http.Handler {
+	failureHandler := opts.FailureHandler
+	if failureHandler == nil {
+		failureHandler = http.HandlerFunc(defaultCSRFFailureHandler)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret, err := csrfSecretFromCookie(r)
+			if err != nil {
+				secret, err = newCSRFSecret()
+				if err != nil {
+					failureHandler.ServeHTTP(w, r)
+					return
+				}
+				setCSRFCookie(w, secret, opts)
+			}
+
+			masked, err := maskCSRFSecret(secret)
+			if err != nil {
+				failureHandler.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set(csrfHeaderName, masked)
+
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				ctx := context.WithValue(r.Context(), csrfContextKey{}, masked)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			if !originAllowed(r, opts.TrustedOrigins) {
+				failureHandler.ServeHTTP(w, r)
+				return
+			}
+
+			submitted := r.Header.Get(csrfHeaderName)
+			if submitted == "" {
+				submitted = r.FormValue(csrfFormField)
+			}
+			if submitted == "" {
+				failureHandler.ServeHTTP(w, r)
+				return
+			}
+
+			submittedSecret, err := unmaskCSRFToken(submitted)
+			if err != nil || subtle.ConstantTimeCompare(submittedSecret, secret) != 1 {
+				failureHandler.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), csrfContextKey{}, masked)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// TemplateField returns the masked CSRF token stashed on the request
+// context by CSRF, for use as the {{ .csrfField }} template helper.
+func TemplateField(r *http.Request) string {
+	token, _ := r.Context().Value(csrfContextKey{}).(string)
+	return token
+}
+
+func defaultCSRFFailureHandler(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "csrf token invalid or missing", http.StatusForbidden)
+}
+
+func newCSRFSecret() ([]byte, error) {
+	secret := make([]byte, csrfSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func setCSRFCookie(w http.ResponseWriter, secret []byte, opts CSRFOptions) {
+	cookie := &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(secret),
+		Path:     "/",
+		Domain:   opts.CookieDomain,
+		Expires:  time.Now().Add(24 * time.Hour),
+		HttpOnly: true,
+		Secure:   opts.Secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+	http.SetCookie(w, cookie)
+}
+
+func csrfSecretFromCookie(r *http.Request) ([]byte, error) {
+	cookie, err := r.Cookie(csrfCookieName)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || len(secret) != csrfSecretBytes {
+		return nil, errInvalidCSRFCookie
+	}
+	return secret, nil
+}
+
+// maskCSRFSecret XORs secret with a fresh random pad and returns
+// base64(pad||masked) so the wire value changes on every request even
+// though the underlying secret does not.
+func maskCSRFSecret(secret []byte) (string, error) {
+	pad := make([]byte, csrfSecretBytes)
+	if _, err := rand.Read(pad); err != nil {
+		return "", err
+	}
+	masked := xorBytes(pad, secret)
+	out := append(pad, masked...)
+	return base64.RawURLEncoding.EncodeToString(out), nil
+}
+
+func unmaskCSRFToken(token string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != csrfSecretBytes*2 {
+		return nil, errInvalidCSRFCookie
+	}
+	pad, masked := raw[:csrfSecretBytes], raw[csrfSecretBytes:]
+	return xorBytes(pad, masked), nil
+}
+
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range out {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+func originAllowed(r *http.Request, trusted []string) bool {
+	if len(trusted) == 0 {
+		return true
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		if ref := r.Header.Get("Referer"); ref != "" {
+			if u, err := url.Parse(ref); err == nil {
+				origin = u.Scheme + "://" + u.Host
+			}
+		}
+	}
+	if origin == "" {
+		return false
+	}
+
+	for _, t := range trusted {
+		if strings.EqualFold(origin, t) {
+			return true
+		}
+	}
+	return false
+}
+
+var errInvalidCSRFCookie = csrfError("invalid csrf cookie")
+
+type csrfError string
+
+func (e csrfError) Error() string { return string(e) }
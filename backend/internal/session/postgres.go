@@ -0,0 +1,54 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+)
+
+// PostgresStore is the Store implementation extracted from the session
+// handling middleware.Authenticate used to do directly against dbConn.
+type PostgresStore struct {
+	dbConn *db.DB
+}
+
+// NewPostgresStore builds a PostgresStore backed by dbConn.
+func NewPostgresStore(dbConn *db.DB) *PostgresStore {
+	return &PostgresStore{dbConn: dbConn}
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id string) (*Session, error) {
+	sess, err := s.dbConn.GetSession(ctx, id)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+	return &Session{
+		ID:        sess.ID,
+		UserID:    sess.UserID,
+		Role:      sess.Role,
+		CreatedAt: sess.CreatedAt,
+		ExpiresAt: sess.ExpiresAt,
+	}, nil
+}
+
+func (s *PostgresStore) Save(ctx context.Context, sess *Session) error {
+	return s.dbConn.UpsertSession(ctx, db.SessionRecord{
+		ID:        sess.ID,
+		UserID:    sess.UserID,
+		Role:      sess.Role,
+		CreatedAt: sess.CreatedAt,
+		ExpiresAt: sess.ExpiresAt,
+	})
+}
+
+func (s *PostgresStore) Destroy(ctx context.Context, id string) error {
+	return s.dbConn.DeleteSession(ctx, id)
+}
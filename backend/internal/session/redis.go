@@ -0,0 +1,59 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "session:"
+
+// RedisStore is a Store implementation backed by Redis, selected via
+// SESSION_STORE=redis. Sessions are stored as JSON with a TTL matching
+// their expiry, so Redis reclaims them without a separate sweeper.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore builds a RedisStore from an already-connected client.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*Session, error) {
+	raw, err := s.client.Get(ctx, redisKeyPrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sess Session
+	if err := json.Unmarshal(raw, &sess); err != nil {
+		return nil, err
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+	return &sess, nil
+}
+
+func (s *RedisStore) Save(ctx context.Context, sess *Session) error {
+	raw, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(sess.ExpiresAt)
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return s.client.Set(ctx, redisKeyPrefix+sess.ID, raw, ttl).Err()
+}
+
+func (s *RedisStore) Destroy(ctx context.Context, id string) error {
+	return s.client.Del(ctx, redisKeyPrefix+id).Err()
+}
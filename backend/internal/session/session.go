@@ -0,0 +1,121 @@
+// Package session defines a pluggable session store and the signed cookie
+// scheme used to reference sessions without exposing raw, enumerable IDs.
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// CookieName is the cookie that carries the signed session ID.
+const CookieName = "session_id"
+
+// DefaultTTL is how long a session is valid when no explicit expiry is set.
+const DefaultTTL = 24 * time.Hour
+
+// ErrNotFound is returned by Store.Get when no session exists for an ID, or
+// it has expired.
+var ErrNotFound = errors.New("session: not found")
+
+// Session is a logged-in user's session, for an admin, buyer, or farmer
+// account (Role distinguishes which table UserID refers to).
+type Session struct {
+	ID        string
+	UserID    int64
+	Role      string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Store resolves, persists, and invalidates sessions. Implementations must
+// treat an expired session as not found.
+type Store interface {
+	Get(ctx context.Context, id string) (*Session, error)
+	Save(ctx context.Context, sess *Session) error
+	Destroy(ctx context.Context, id string) error
+}
+
+// New builds a Session for userID/role with a freshly generated, signed ID,
+// ready to be saved and set on a response. ttl should come from
+// config.Config.SessionTTL; a zero ttl falls back to DefaultTTL.
+func New(signer *Signer, userID int64, role string, ttl time.Duration) (*Session, error) {
+	id, err := signer.NewID()
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	now := time.Now()
+	return &Session{
+		ID:        id,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// SetCookie writes sess's signed ID to an HttpOnly, SameSite=Lax cookie.
+// secure should come from config.Config.SecureCookies — false only in
+// local development, since browsers drop Secure cookies over plain
+// http://localhost.
+func SetCookie(w http.ResponseWriter, signer *Signer, sess *Session, secure bool) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    signer.Sign(sess.ID),
+		Path:     "/",
+		Expires:  sess.ExpiresAt,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ClearCookie expires the session cookie, e.g. on logout.
+func ClearCookie(w http.ResponseWriter, secure bool) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// IDFromRequest reads the session cookie and verifies its signature,
+// returning the underlying session ID to look up in a Store.
+func IDFromRequest(r *http.Request, signer *Signer) (string, error) {
+	cookie, err := r.Cookie(CookieName)
+	if err != nil {
+		return "", ErrNotFound
+	}
+	id, ok := signer.Verify(cookie.Value)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return id, nil
+}
+
+// contextKey is unexported so NewContext/FromContext are the only way to
+// set or read the authenticated session on a request context.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying sess, for middleware.Authenticate
+// to attach the resolved session to the request before calling the next
+// handler.
+func NewContext(ctx context.Context, sess *Session) context.Context {
+	return context.WithValue(ctx, contextKey{}, sess)
+}
+
+// FromContext returns the session attached by middleware.Authenticate, if
+// any. Handlers behind middleware.Authenticate can assume ok is true.
+func FromContext(ctx context.Context) (*Session, bool) {
+	sess, ok := ctx.Value(contextKey{}).(*Session)
+	return sess, ok
+}
@@ -0,0 +1,24 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/config"
+	"github.com/Neroframe/FarmerMarketSystem/backend/internal/db"
+)
+
+// NewStore builds the Store selected by cfg.SessionStore ("postgres" or
+// "redis").
+func NewStore(cfg *config.Config, dbConn *db.DB) (Store, error) {
+	switch cfg.SessionStore {
+	case "", "postgres":
+		return NewPostgresStore(dbConn), nil
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+		return NewRedisStore(client), nil
+	default:
+		return nil, fmt.Errorf("session: unknown SESSION_STORE %q", cfg.SessionStore)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 )
 
+// Deprecated: superseded by middleware.CSRF, which issues a masked,
+// origin-checked token instead of an unsigned SameSite=None cookie.
 func SetCSRFToken(w http.ResponseWriter) (string, error) {
 	tokenBytes := make([]byte, 32)
 	_, err := rand.Read(tokenBytes)
@@ -30,6 +32,9 @@ func SetCSRFToken(w http.ResponseWriter) (string, error) {
 	return token, nil
 }
 
+// Deprecated: superseded by middleware.CSRF; handlers should no longer
+// validate CSRF tokens themselves.
+//
 // Compares the CSRF tokens (form with cookie)
 func ValidateCSRFToken(r *http.Request) error {
 	formToken := r.FormValue("csrf_token")